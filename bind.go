@@ -0,0 +1,265 @@
+package envy
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Setter can be implemented by a field type to take full control of how it is
+// populated from an environment variable value. If a field's type (or a
+// pointer to it) implements Setter, Bind will call SetEnv with the raw string
+// value instead of applying its own conversion rules.
+type Setter interface {
+	SetEnv(string) error
+}
+
+// setterType is used to detect, via reflection, whether a pointer type
+// implements Setter without first having to dereference a possibly-nil
+// pointer value.
+var setterType = reflect.TypeOf((*Setter)(nil)).Elem()
+
+// bindError is a single field-level failure collected while processing a
+// struct passed to Bind.
+type bindError struct {
+	field string
+	err   error
+}
+
+func (e *bindError) Error() string {
+	return fmt.Sprintf("%s: %s", e.field, e.err)
+}
+
+func (e *bindError) Unwrap() error {
+	return e.err
+}
+
+// BindErrors is returned by Bind when one or more fields could not be
+// populated. It aggregates every failure encountered rather than stopping at
+// the first one, so all problems with a configuration can be reported at
+// once.
+type BindErrors []error
+
+func (e BindErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return fmt.Sprintf("envy: %d error(s) binding struct:\n\t%s", len(e), strings.Join(msgs, "\n\t"))
+}
+
+// Bind populates the fields of the struct pointed to by v from environment
+// variables, using the `env` struct tag to determine the variable name for
+// each field. It supports the same scalar types as the package's XVar
+// functions (string, int/int64, uint/uint64, float64, bool, time.Duration),
+// plus slices of those types and nested structs.
+//
+// Recognised struct tags are:
+//
+//	env:"NAME"       the environment variable to read for this field
+//	default:"VALUE"  the value to use if the env variable is not set
+//	required:"true"  fail if the env variable is not set and no default is given
+//	separator:","    the separator used to split slice values (default ",")
+//	prefix:"DB_"     on a nested/embedded struct, prepend to all its children's names
+//
+// Unlike the package-level XVar functions, Bind never panics. Every field
+// that fails to bind is collected and returned together as a BindErrors, so a
+// caller can report every problem with a configuration in one pass.
+//
+// Struct fields that do not implement Setter are always recursed into, with
+// or without an env tag. Other fields without an env tag are ignored, as are
+// unexported fields. A field
+// whose type implements Setter is populated by calling SetEnv with the raw
+// string value, bypassing Bind's own type conversion.
+func Bind(v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("envy: Bind requires a non-nil pointer to a struct, got %T", v)
+	}
+
+	var errs BindErrors
+
+	bindStruct(rv.Elem(), "", &errs)
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// Unmarshal is an alias of Bind, provided for parity with the naming used by
+// encoding packages such as encoding/json.
+func Unmarshal(v any) error {
+	return Bind(v)
+}
+
+func bindStruct(rv reflect.Value, prefix string, errs *BindErrors) {
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		fv := rv.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		_, isSetter := asSetter(fv)
+
+		if fv.Kind() == reflect.Struct && !isSetter {
+			childPrefix := prefix + field.Tag.Get("prefix")
+			bindStruct(fv, childPrefix, errs)
+
+			continue
+		}
+
+		name, ok := field.Tag.Lookup("env")
+		if !ok {
+			continue
+		}
+		name = prefix + name
+
+		if err := bindField(fv, field, name); err != nil {
+			*errs = append(*errs, &bindError{field: field.Name, err: err})
+		}
+	}
+}
+
+func bindField(fv reflect.Value, field reflect.StructField, name string) error {
+	// A nil pointer field whose type implements Setter (via a pointer or
+	// value receiver) would otherwise be handed to SetEnv as-is, and any
+	// implementation that touches the receiver would nil-dereference. Give
+	// it somewhere to point first.
+	if fv.Kind() == reflect.Pointer && fv.IsNil() && fv.CanSet() && fv.Type().Implements(setterType) {
+		fv.Set(reflect.New(fv.Type().Elem()))
+	}
+
+	if setter, ok := asSetter(fv); ok {
+		raw, present := os.LookupEnv(name)
+		if !present {
+			raw = field.Tag.Get("default")
+			if raw == "" && field.Tag.Get("required") == "true" {
+				return fmt.Errorf("required env variable %s is not set", name)
+			}
+		}
+
+		return setter.SetEnv(raw)
+	}
+
+	raw, present := os.LookupEnv(name)
+	if !present {
+		if def, ok := field.Tag.Lookup("default"); ok {
+			raw = def
+			present = true
+		} else if field.Tag.Get("required") == "true" {
+			return fmt.Errorf("required env variable %s is not set", name)
+		} else {
+			return nil
+		}
+	}
+
+	if fv.Kind() == reflect.Slice {
+		return bindSlice(fv, raw, field.Tag.Get("separator"))
+	}
+
+	return bindScalar(fv, raw)
+}
+
+func bindSlice(fv reflect.Value, raw, separator string) error {
+	if separator == "" {
+		separator = ","
+	}
+
+	if raw == "" {
+		fv.Set(reflect.MakeSlice(fv.Type(), 0, 0))
+
+		return nil
+	}
+
+	parts := strings.Split(raw, separator)
+	out := reflect.MakeSlice(fv.Type(), len(parts), len(parts))
+
+	for i, part := range parts {
+		if err := bindScalar(out.Index(i), strings.TrimSpace(part)); err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+
+	fv.Set(out)
+
+	return nil
+}
+
+func bindScalar(fv reflect.Value, raw string) error {
+	switch fv.Interface().(type) {
+	case time.Duration:
+		d, err := castDuration(raw)
+		if err != nil {
+			return err
+		}
+
+		fv.Set(reflect.ValueOf(d))
+
+		return nil
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := castInt64(raw)
+		if err != nil {
+			return err
+		}
+
+		fv.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := castUint64(raw)
+		if err != nil {
+			return err
+		}
+
+		fv.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := castFloat64(raw)
+		if err != nil {
+			return err
+		}
+
+		fv.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+
+		fv.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+
+	return nil
+}
+
+// asSetter reports whether fv (or its address) implements Setter, returning
+// the implementation to call if so.
+func asSetter(fv reflect.Value) (Setter, bool) {
+	if fv.CanInterface() {
+		if s, ok := fv.Interface().(Setter); ok {
+			return s, true
+		}
+	}
+
+	if fv.CanAddr() && fv.Addr().CanInterface() {
+		if s, ok := fv.Addr().Interface().(Setter); ok {
+			return s, true
+		}
+	}
+
+	return nil, false
+}
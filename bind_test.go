@@ -0,0 +1,233 @@
+package envy
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type csvValue struct {
+	fields []string
+}
+
+// SetEnv has a pointer receiver, so only *csvValue implements Setter; Bind
+// reaches it through the field's address.
+func (c *csvValue) SetEnv(s string) error {
+	c.fields = strings.Split(s, ",")
+
+	return nil
+}
+
+type upperValue string
+
+// SetEnv has a pointer receiver.
+func (u *upperValue) SetEnv(s string) error {
+	*u = upperValue(strings.ToUpper(s))
+
+	return nil
+}
+
+func TestBindNestedStructWithPrefix(t *testing.T) {
+	t.Setenv("DB_HOST", "db.example.com")
+	t.Setenv("DB_PORT", "5432")
+
+	type DB struct {
+		Host string `env:"HOST"`
+		Port int    `env:"PORT"`
+	}
+
+	type Config struct {
+		DB DB `prefix:"DB_"`
+	}
+
+	var cfg Config
+	if err := Bind(&cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.DB.Host != "db.example.com" {
+		t.Errorf("DB.Host = %q, want %q", cfg.DB.Host, "db.example.com")
+	}
+
+	if cfg.DB.Port != 5432 {
+		t.Errorf("DB.Port = %d, want %d", cfg.DB.Port, 5432)
+	}
+}
+
+func TestBindSlice(t *testing.T) {
+	t.Setenv("TAGS", "a, b ,c")
+
+	type Config struct {
+		Tags []string `env:"TAGS"`
+	}
+
+	var cfg Config
+	if err := Bind(&cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"a", "b", "c"}
+	if fmt.Sprint(cfg.Tags) != fmt.Sprint(want) {
+		t.Errorf("Tags = %v, want %v", cfg.Tags, want)
+	}
+}
+
+func TestBindSliceCustomSeparator(t *testing.T) {
+	t.Setenv("IDS", "1|2|3")
+
+	type Config struct {
+		IDs []int `env:"IDS" separator:"|"`
+	}
+
+	var cfg Config
+	if err := Bind(&cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []int{1, 2, 3}
+	if fmt.Sprint(cfg.IDs) != fmt.Sprint(want) {
+		t.Errorf("IDs = %v, want %v", cfg.IDs, want)
+	}
+}
+
+func TestBindRequiredMissing(t *testing.T) {
+	type Config struct {
+		Name string `env:"ENVY_TEST_BIND_MISSING_REQUIRED" required:"true"`
+	}
+
+	var cfg Config
+
+	err := Bind(&cfg)
+	if err == nil {
+		t.Fatal("expected an error for a missing required field")
+	}
+
+	var errs BindErrors
+	if !errorsAs(err, &errs) {
+		t.Fatalf("expected BindErrors, got %T: %v", err, err)
+	}
+
+	if len(errs) != 1 {
+		t.Fatalf("expected exactly one error, got %d: %v", len(errs), errs)
+	}
+}
+
+func TestBindDefaultUsedWhenUnset(t *testing.T) {
+	type Config struct {
+		Name string `env:"ENVY_TEST_BIND_DEFAULT_UNSET" default:"fallback"`
+	}
+
+	var cfg Config
+	if err := Bind(&cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Name != "fallback" {
+		t.Errorf("Name = %q, want %q", cfg.Name, "fallback")
+	}
+}
+
+func TestBindDefaultDoesNotOverrideRequiredWhenSet(t *testing.T) {
+	t.Setenv("ENVY_TEST_BIND_REQUIRED_SET", "present")
+
+	type Config struct {
+		Name string `env:"ENVY_TEST_BIND_REQUIRED_SET" required:"true" default:"fallback"`
+	}
+
+	var cfg Config
+	if err := Bind(&cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Name != "present" {
+		t.Errorf("Name = %q, want %q", cfg.Name, "present")
+	}
+}
+
+func TestBindAggregatesMultipleErrors(t *testing.T) {
+	type Config struct {
+		A string `env:"ENVY_TEST_BIND_MISSING_A" required:"true"`
+		B string `env:"ENVY_TEST_BIND_MISSING_B" required:"true"`
+	}
+
+	var cfg Config
+
+	err := Bind(&cfg)
+
+	var errs BindErrors
+	if !errorsAs(err, &errs) {
+		t.Fatalf("expected BindErrors, got %T: %v", err, err)
+	}
+
+	if len(errs) != 2 {
+		t.Fatalf("expected both missing required fields to be reported, got %d: %v", len(errs), errs)
+	}
+}
+
+func TestBindSetterValueField(t *testing.T) {
+	t.Setenv("CSV", "x,y,z")
+
+	type Config struct {
+		CSV csvValue `env:"CSV"`
+	}
+
+	var cfg Config
+	if err := Bind(&cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"x", "y", "z"}
+	if fmt.Sprint(cfg.CSV.fields) != fmt.Sprint(want) {
+		t.Errorf("CSV.fields = %v, want %v", cfg.CSV.fields, want)
+	}
+}
+
+func TestBindSetterNilPointerField(t *testing.T) {
+	t.Setenv("CSVPTR", "x,y,z")
+
+	type Config struct {
+		CSV *csvValue `env:"CSVPTR"`
+	}
+
+	var cfg Config
+	if err := Bind(&cfg); err != nil {
+		t.Fatalf("Bind should allocate a nil pointer field before calling SetEnv, got: %v", err)
+	}
+
+	if cfg.CSV == nil {
+		t.Fatal("CSV should have been allocated")
+	}
+
+	want := []string{"x", "y", "z"}
+	if fmt.Sprint(cfg.CSV.fields) != fmt.Sprint(want) {
+		t.Errorf("CSV.fields = %v, want %v", cfg.CSV.fields, want)
+	}
+}
+
+func TestBindSetterNilPointerFieldUnset(t *testing.T) {
+	type Config struct {
+		Name *upperValue `env:"ENVY_TEST_BIND_SETTER_PTR_UNSET"`
+	}
+
+	var cfg Config
+	if err := Bind(&cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Name == nil {
+		t.Fatal("Name should have been allocated even though unset, to receive the empty default")
+	}
+}
+
+// errorsAs reports whether err is itself a BindErrors and, if so, stores it in
+// target. Unlike errors.As, it does not unwrap err.
+func errorsAs(err error, target *BindErrors) bool {
+	errs, ok := err.(BindErrors)
+	if !ok {
+		return false
+	}
+
+	*target = errs
+
+	return true
+}
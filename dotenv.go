@@ -0,0 +1,221 @@
+package envy
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// EnvyFiles is the name of the env variable that, if set, provides a
+// comma-separated fallback list of .env files to load when Load or Overload
+// is called without any paths.
+const EnvyFiles = "ENVY_FILES"
+
+// Load reads each of the given .env files in order and sets the variables
+// they define via os.Setenv, without overwriting any variable that is
+// already present in the environment. If no paths are given, the paths
+// listed in the ENVY_FILES env variable (comma-separated) are used instead.
+//
+// Load (and Overload) are intended to be called before Parse, so that the
+// values they set are visible to the queued XVar calls when Parse fans them
+// out. See Parse for more on this ordering.
+func Load(paths ...string) error {
+	return load(paths, false)
+}
+
+// Overload behaves like Load, except that variables defined in the .env
+// files take precedence over (and overwrite) any variable already present in
+// the environment.
+func Overload(paths ...string) error {
+	return load(paths, true)
+}
+
+func load(paths []string, overload bool) error {
+	if len(paths) == 0 {
+		paths = envyFilesFallback()
+	}
+
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("envy: failed to open %s: %w", path, err)
+		}
+
+		err = loadReader(f, overload)
+		f.Close()
+
+		if err != nil {
+			return fmt.Errorf("envy: failed to load %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// LoadReader parses .env formatted content from r and sets the variables it
+// defines via os.Setenv, without overwriting any variable that is already
+// present in the environment. It is useful for sources that aren't plain
+// files on disk, such as an embedded fs.FS.
+func LoadReader(r io.Reader) error {
+	return loadReader(r, false)
+}
+
+func envyFilesFallback() []string {
+	v, ok := os.LookupEnv(EnvyFiles)
+	if !ok || v == "" {
+		return nil
+	}
+
+	paths := strings.Split(v, ",")
+	for i := range paths {
+		paths[i] = strings.TrimSpace(paths[i])
+	}
+
+	return paths
+}
+
+func loadReader(r io.Reader, overload bool) error {
+	pairs, err := parseDotenv(r)
+	if err != nil {
+		return err
+	}
+
+	for _, kv := range pairs {
+		if !overload {
+			if _, set := os.LookupEnv(kv.key); set {
+				continue
+			}
+		}
+
+		if err := os.Setenv(kv.key, kv.value); err != nil {
+			return fmt.Errorf("failed to set %s: %w", kv.key, err)
+		}
+	}
+
+	return nil
+}
+
+type dotenvPair struct {
+	key   string
+	value string
+}
+
+// parseDotenv parses KEY=VALUE formatted lines, resolving ${VAR} references
+// against variables defined earlier in the same source (or already present
+// in the environment if not). Lines are resolved and applied in order, so
+// that later definitions can interpolate earlier ones.
+func parseDotenv(r io.Reader) ([]dotenvPair, error) {
+	var pairs []dotenvPair
+	resolved := make(map[string]string)
+
+	scanner := bufio.NewScanner(r)
+
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, literal, err := splitDotenvLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+
+		if !literal {
+			value = interpolate(value, resolved)
+		}
+
+		resolved[key] = value
+
+		pairs = append(pairs, dotenvPair{key: key, value: value})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return pairs, nil
+}
+
+func splitDotenvLine(line string) (key, value string, literal bool, err error) {
+	idx := strings.Index(line, "=")
+	if idx < 0 {
+		return "", "", false, fmt.Errorf("expected KEY=VALUE, got %q", line)
+	}
+
+	key = strings.TrimSpace(line[:idx])
+	if key == "" {
+		return "", "", false, fmt.Errorf("empty key in %q", line)
+	}
+
+	value = strings.TrimSpace(line[idx+1:])
+	value, literal, err = unquote(value)
+
+	return key, value, literal, err
+}
+
+// unquote strips a single matching pair of surrounding quotes from value. If
+// value is double-quoted, \n escapes within it are expanded; single-quoted
+// values are taken completely literally, which unquote reports via the
+// literal return value so that callers know to skip ${VAR} interpolation.
+func unquote(value string) (_ string, literal bool, _ error) {
+	if len(value) < 2 {
+		return value, false, nil
+	}
+
+	switch value[0] {
+	case '"':
+		if value[len(value)-1] != '"' {
+			return "", false, fmt.Errorf("unterminated double-quoted value: %q", value)
+		}
+
+		inner := value[1 : len(value)-1]
+		inner = strings.ReplaceAll(inner, `\n`, "\n")
+		inner = strings.ReplaceAll(inner, `\"`, `"`)
+
+		return inner, false, nil
+	case '\'':
+		if value[len(value)-1] != '\'' {
+			return "", false, fmt.Errorf("unterminated single-quoted value: %q", value)
+		}
+
+		return value[1 : len(value)-1], true, nil
+	default:
+		return value, false, nil
+	}
+}
+
+// interpolate expands ${VAR} references in value, preferring a value that
+// was already resolved earlier in the same .env file over one already set
+// in the environment.
+func interpolate(value string, resolved map[string]string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(value); i++ {
+		if value[i] == '$' && i+1 < len(value) && value[i+1] == '{' {
+			end := strings.IndexByte(value[i+2:], '}')
+			if end >= 0 {
+				name := value[i+2 : i+2+end]
+
+				if v, ok := resolved[name]; ok {
+					b.WriteString(v)
+				} else if v, ok := os.LookupEnv(name); ok {
+					b.WriteString(v)
+				}
+
+				i += 2 + end
+
+				continue
+			}
+		}
+
+		b.WriteByte(value[i])
+	}
+
+	return b.String()
+}
@@ -0,0 +1,120 @@
+package envy
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseDotenv(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{
+			name: "basic key value",
+			input: `
+FOO=bar
+BAZ=qux
+`,
+			want: map[string]string{"FOO": "bar", "BAZ": "qux"},
+		},
+		{
+			name: "comments and blank lines are ignored",
+			input: `
+# a comment
+FOO=bar
+
+# another comment
+BAZ=qux
+`,
+			want: map[string]string{"FOO": "bar", "BAZ": "qux"},
+		},
+		{
+			name:  "export prefix is stripped",
+			input: "export FOO=bar\n",
+			want:  map[string]string{"FOO": "bar"},
+		},
+		{
+			name:  "double-quoted value expands \\n",
+			input: `FOO="line one\nline two"`,
+			want:  map[string]string{"FOO": "line one\nline two"},
+		},
+		{
+			name:  "double-quoted value interpolates earlier vars",
+			input: "BAR=world\nFOO=\"hello ${BAR}\"",
+			want:  map[string]string{"BAR": "world", "FOO": "hello world"},
+		},
+		{
+			name:  "unquoted value interpolates earlier vars",
+			input: "BAR=world\nFOO=hello ${BAR}",
+			want:  map[string]string{"BAR": "world", "FOO": "hello world"},
+		},
+		{
+			name:  "single-quoted value is taken literally, no interpolation",
+			input: "BAR=world\nFOO='literal-${BAR}'",
+			want:  map[string]string{"BAR": "world", "FOO": "literal-${BAR}"},
+		},
+		{
+			name:    "unterminated double quote is an error",
+			input:   `FOO="unterminated`,
+			wantErr: true,
+		},
+		{
+			name:    "unterminated single quote is an error",
+			input:   `FOO='unterminated`,
+			wantErr: true,
+		},
+		{
+			name:    "missing equals is an error",
+			input:   "NOTANASSIGNMENT",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pairs, err := parseDotenv(strings.NewReader(tt.input))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got none")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := make(map[string]string, len(pairs))
+			for _, p := range pairs {
+				got[p.key] = p.value
+			}
+
+			for k, want := range tt.want {
+				if got[k] != want {
+					t.Errorf("%s = %q, want %q", k, got[k], want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadReader(t *testing.T) {
+	t.Setenv("EXISTING", "already-set")
+
+	err := LoadReader(strings.NewReader("EXISTING=overwritten\nNEWVAR=value\n"))
+	if err != nil {
+		t.Fatalf("LoadReader returned an error: %v", err)
+	}
+
+	if got := String("EXISTING", ""); got != "already-set" {
+		t.Errorf("Load should not overwrite an existing env var, got %q", got)
+	}
+
+	if got := String("NEWVAR", ""); got != "value" {
+		t.Errorf("NEWVAR = %q, want %q", got, "value")
+	}
+}
@@ -0,0 +1,232 @@
+package envy
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+// Var describes a single variable that has been queued against a Set: its
+// name (and any aliases registered via an XVarN function), the Go type it is
+// converted to, its default value, and an optional usage string.
+type Var struct {
+	Name    string
+	Aliases []string
+	Type    string
+	Default string
+	Usage   string
+}
+
+// IsSet reports whether Name, or any of Aliases, is currently present in the
+// environment.
+func (v *Var) IsSet() bool {
+	for _, name := range append([]string{v.Name}, v.Aliases...) {
+		if _, ok := os.LookupEnv(name); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newVar[T any](name string, defaultValue T, usage string) *Var {
+	return &Var{
+		Name:    name,
+		Type:    reflect.TypeOf(defaultValue).String(),
+		Default: fmt.Sprintf("%v", defaultValue),
+		Usage:   usage,
+	}
+}
+
+// Visit calls fn for every Var queued against s, in the order they were
+// registered.
+func (s *Set) Visit(fn func(v *Var)) {
+	for _, v := range s.list {
+		fn(v)
+	}
+}
+
+// Lookup returns the Var registered under name (matching either its primary
+// name or one of its aliases), or nil if no such Var has been queued.
+func (s *Set) Lookup(name string) *Var {
+	for _, v := range s.list {
+		if v.Name == name {
+			return v
+		}
+
+		for _, alias := range v.Aliases {
+			if alias == name {
+				return v
+			}
+		}
+	}
+
+	return nil
+}
+
+// PrintDefaults writes a human-readable table of every Var queued against s
+// to w: its name, type, default value, usage (if any), and whether it is
+// currently set in the environment.
+func (s *Set) PrintDefaults(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "NAME\tTYPE\tDEFAULT\tSET\tUSAGE")
+
+	s.Visit(func(v *Var) {
+		name := v.Name
+		if len(v.Aliases) > 0 {
+			name = fmt.Sprintf("%s (%s)", name, joinAliases(v.Aliases))
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%q\t%t\t%s\n", name, v.Type, v.Default, v.IsSet(), v.Usage)
+	})
+
+	tw.Flush()
+}
+
+func joinAliases(aliases []string) string {
+	out := aliases[0]
+	for _, a := range aliases[1:] {
+		out += ", " + a
+	}
+
+	return out
+}
+
+// Visit calls fn for every Var queued against Default, in the order they
+// were registered.
+func Visit(fn func(v *Var)) {
+	Default.Visit(fn)
+}
+
+// Lookup returns the Var registered against Default under name, or nil if no
+// such Var has been queued.
+func Lookup(name string) *Var {
+	return Default.Lookup(name)
+}
+
+// PrintDefaults writes a human-readable table of every Var queued against
+// Default to w. It is intended to let a program generate `--help`-style
+// documentation of the environment variables it expects.
+func PrintDefaults(w io.Writer) {
+	Default.PrintDefaults(w)
+}
+
+// SetterVar queues setter, a custom Setter implementation, to be populated
+// from the env variable name when Parse is called. Unlike the XVar
+// functions, there is no separate default value: setter should already hold
+// its zero/default value before SetterVar is called, matching the behavior
+// of flag.Var.
+func (s *Set) SetterVar(setter Setter, name, usage string) {
+	s.vars = append(s.vars, func() error {
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			return nil
+		}
+
+		if err := setter.SetEnv(v); err != nil {
+			return &VarError{Name: name, Type: "envy.Setter", Err: err}
+		}
+
+		return nil
+	})
+
+	s.list = append(s.list, &Var{Name: name, Type: "envy.Setter", Usage: usage})
+}
+
+// SetterVar queues setter against Default. See Set.SetterVar for details.
+func SetterVar(setter Setter, name, usage string) {
+	Default.SetterVar(setter, name, usage)
+}
+
+// StringVarUsage behaves like StringVar, but additionally records usage so
+// that it shows up in PrintDefaults.
+func (s *Set) StringVarUsage(addr *string, name, defaultValue, usage string) {
+	queueSetVarUsage(s, addr, name, defaultValue, usage, castString)
+}
+
+// IntVarUsage behaves like IntVar, but additionally records usage so that it
+// shows up in PrintDefaults.
+func (s *Set) IntVarUsage(addr *int, name string, defaultValue int, usage string) {
+	queueSetVarUsage(s, addr, name, defaultValue, usage, strconv.Atoi)
+}
+
+// Int64VarUsage behaves like Int64Var, but additionally records usage so
+// that it shows up in PrintDefaults.
+func (s *Set) Int64VarUsage(addr *int64, name string, defaultValue int64, usage string) {
+	queueSetVarUsage(s, addr, name, defaultValue, usage, castInt64)
+}
+
+// UintVarUsage behaves like UintVar, but additionally records usage so that
+// it shows up in PrintDefaults.
+func (s *Set) UintVarUsage(addr *uint, name string, defaultValue uint, usage string) {
+	queueSetVarUsage(s, addr, name, defaultValue, usage, castUint)
+}
+
+// Uint64VarUsage behaves like Uint64Var, but additionally records usage so
+// that it shows up in PrintDefaults.
+func (s *Set) Uint64VarUsage(addr *uint64, name string, defaultValue uint64, usage string) {
+	queueSetVarUsage(s, addr, name, defaultValue, usage, castUint64)
+}
+
+// Float64VarUsage behaves like Float64Var, but additionally records usage
+// so that it shows up in PrintDefaults.
+func (s *Set) Float64VarUsage(addr *float64, name string, defaultValue float64, usage string) {
+	queueSetVarUsage(s, addr, name, defaultValue, usage, castFloat64)
+}
+
+// BoolVarUsage behaves like BoolVar, but additionally records usage so that
+// it shows up in PrintDefaults.
+func (s *Set) BoolVarUsage(addr *bool, name string, defaultValue bool, usage string) {
+	queueSetVarUsage(s, addr, name, defaultValue, usage, strconv.ParseBool)
+}
+
+// DurationVarUsage behaves like DurationVar, but additionally records usage
+// so that it shows up in PrintDefaults.
+func (s *Set) DurationVarUsage(addr *time.Duration, name string, defaultValue time.Duration, usage string) {
+	queueSetVarUsage(s, addr, name, defaultValue, usage, castDuration)
+}
+
+// StringVarUsage queues addr against Default. See Set.StringVarUsage.
+func StringVarUsage(addr *string, name, defaultValue, usage string) {
+	Default.StringVarUsage(addr, name, defaultValue, usage)
+}
+
+// IntVarUsage queues addr against Default. See Set.IntVarUsage.
+func IntVarUsage(addr *int, name string, defaultValue int, usage string) {
+	Default.IntVarUsage(addr, name, defaultValue, usage)
+}
+
+// Int64VarUsage queues addr against Default. See Set.Int64VarUsage.
+func Int64VarUsage(addr *int64, name string, defaultValue int64, usage string) {
+	Default.Int64VarUsage(addr, name, defaultValue, usage)
+}
+
+// UintVarUsage queues addr against Default. See Set.UintVarUsage.
+func UintVarUsage(addr *uint, name string, defaultValue uint, usage string) {
+	Default.UintVarUsage(addr, name, defaultValue, usage)
+}
+
+// Uint64VarUsage queues addr against Default. See Set.Uint64VarUsage.
+func Uint64VarUsage(addr *uint64, name string, defaultValue uint64, usage string) {
+	Default.Uint64VarUsage(addr, name, defaultValue, usage)
+}
+
+// Float64VarUsage queues addr against Default. See Set.Float64VarUsage.
+func Float64VarUsage(addr *float64, name string, defaultValue float64, usage string) {
+	Default.Float64VarUsage(addr, name, defaultValue, usage)
+}
+
+// BoolVarUsage queues addr against Default. See Set.BoolVarUsage.
+func BoolVarUsage(addr *bool, name string, defaultValue bool, usage string) {
+	Default.BoolVarUsage(addr, name, defaultValue, usage)
+}
+
+// DurationVarUsage queues addr against Default. See Set.DurationVarUsage.
+func DurationVarUsage(addr *time.Duration, name string, defaultValue time.Duration, usage string) {
+	Default.DurationVarUsage(addr, name, defaultValue, usage)
+}
@@ -0,0 +1,98 @@
+package envy
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestVisitAndLookup(t *testing.T) {
+	s := NewSet("test", ContinueOnError)
+
+	var port int
+	s.IntVarUsage(&port, "ENVY_TEST_INTRO_PORT", 8080, "the port to listen on")
+
+	var names []string
+	s.Visit(func(v *Var) {
+		names = append(names, v.Name)
+	})
+
+	if len(names) != 1 || names[0] != "ENVY_TEST_INTRO_PORT" {
+		t.Fatalf("Visit saw %v, want [ENVY_TEST_INTRO_PORT]", names)
+	}
+
+	v := s.Lookup("ENVY_TEST_INTRO_PORT")
+	if v == nil {
+		t.Fatal("Lookup returned nil for a registered var")
+	}
+
+	if v.Usage != "the port to listen on" {
+		t.Errorf("Usage = %q, want %q", v.Usage, "the port to listen on")
+	}
+
+	if v.Default != "8080" {
+		t.Errorf("Default = %q, want %q", v.Default, "8080")
+	}
+
+	if v.Type != "int" {
+		t.Errorf("Type = %q, want %q", v.Type, "int")
+	}
+
+	if s.Lookup("ENVY_TEST_INTRO_NOPE") != nil {
+		t.Error("Lookup should return nil for an unregistered name")
+	}
+}
+
+func TestLookupByAlias(t *testing.T) {
+	s := NewSet("test", ContinueOnError)
+
+	var addr string
+	s.StringVarN(&addr, []string{"ENVY_TEST_INTRO_HTTP_ADDR", "ENVY_TEST_INTRO_LISTEN_ADDR"}, ":8080")
+
+	v := s.Lookup("ENVY_TEST_INTRO_LISTEN_ADDR")
+	if v == nil {
+		t.Fatal("Lookup should find a Var by one of its aliases")
+	}
+
+	if v.Name != "ENVY_TEST_INTRO_HTTP_ADDR" {
+		t.Errorf("Name = %q, want the primary name %q", v.Name, "ENVY_TEST_INTRO_HTTP_ADDR")
+	}
+}
+
+func TestVarIsSet(t *testing.T) {
+	t.Setenv("ENVY_TEST_INTRO_IS_SET", "1")
+
+	s := NewSet("test", ContinueOnError)
+
+	var set, unset int
+	s.IntVar(&set, "ENVY_TEST_INTRO_IS_SET", 0)
+	s.IntVar(&unset, "ENVY_TEST_INTRO_IS_UNSET", 0)
+
+	if !s.Lookup("ENVY_TEST_INTRO_IS_SET").IsSet() {
+		t.Error("expected IsSet to report true for a set var")
+	}
+
+	if s.Lookup("ENVY_TEST_INTRO_IS_UNSET").IsSet() {
+		t.Error("expected IsSet to report false for an unset var")
+	}
+}
+
+func TestPrintDefaults(t *testing.T) {
+	t.Setenv("ENVY_TEST_INTRO_PRINT_PORT", "9090")
+
+	s := NewSet("test", ContinueOnError)
+
+	var port int
+	s.IntVarUsage(&port, "ENVY_TEST_INTRO_PRINT_PORT", 8080, "the port to listen on")
+
+	var buf bytes.Buffer
+	s.PrintDefaults(&buf)
+
+	out := buf.String()
+
+	for _, want := range []string{"ENVY_TEST_INTRO_PRINT_PORT", "int", "8080", "true", "the port to listen on"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("PrintDefaults output missing %q:\n%s", want, out)
+		}
+	}
+}
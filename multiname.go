@@ -0,0 +1,165 @@
+package envy
+
+import (
+	"log"
+	"os"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// lookupN returns the value of the first name in names that is present in
+// the environment, along with which name matched. It honors the same
+// "set but empty" semantics as the single-name lookups: a name that is set
+// to an empty string still counts as present and is returned as-is.
+func lookupN(names []string) (value, matched string, ok bool) {
+	for _, name := range names {
+		if v, ok := os.LookupEnv(name); ok {
+			return v, name, true
+		}
+	}
+
+	return "", "", false
+}
+
+// StringVarN behaves like StringVar, but accepts an ordered list of names.
+// Parse uses the value of the first name present in the environment,
+// logging a deprecation warning if the matched name isn't names[0].
+func (s *Set) StringVarN(addr *string, names []string, defaultValue string) {
+	queueSetVarN(s, addr, names, defaultValue, castString)
+}
+
+// IntVarN behaves like IntVar, but accepts an ordered list of names. Parse
+// uses the value of the first name present in the environment, logging a
+// deprecation warning if the matched name isn't names[0].
+func (s *Set) IntVarN(addr *int, names []string, defaultValue int) {
+	queueSetVarN(s, addr, names, defaultValue, strconv.Atoi)
+}
+
+// Int64VarN behaves like Int64Var, but accepts an ordered list of names.
+// Parse uses the value of the first name present in the environment,
+// logging a deprecation warning if the matched name isn't names[0].
+func (s *Set) Int64VarN(addr *int64, names []string, defaultValue int64) {
+	queueSetVarN(s, addr, names, defaultValue, castInt64)
+}
+
+// UintVarN behaves like UintVar, but accepts an ordered list of names. Parse
+// uses the value of the first name present in the environment, logging a
+// deprecation warning if the matched name isn't names[0].
+func (s *Set) UintVarN(addr *uint, names []string, defaultValue uint) {
+	queueSetVarN(s, addr, names, defaultValue, castUint)
+}
+
+// Uint64VarN behaves like Uint64Var, but accepts an ordered list of names.
+// Parse uses the value of the first name present in the environment, logging
+// a deprecation warning if the matched name isn't names[0].
+func (s *Set) Uint64VarN(addr *uint64, names []string, defaultValue uint64) {
+	queueSetVarN(s, addr, names, defaultValue, castUint64)
+}
+
+// Float64VarN behaves like Float64Var, but accepts an ordered list of names.
+// Parse uses the value of the first name present in the environment, logging
+// a deprecation warning if the matched name isn't names[0].
+func (s *Set) Float64VarN(addr *float64, names []string, defaultValue float64) {
+	queueSetVarN(s, addr, names, defaultValue, castFloat64)
+}
+
+// BoolVarN behaves like BoolVar, but accepts an ordered list of names. Parse
+// uses the value of the first name present in the environment, logging a
+// deprecation warning if the matched name isn't names[0].
+func (s *Set) BoolVarN(addr *bool, names []string, defaultValue bool) {
+	queueSetVarN(s, addr, names, defaultValue, strconv.ParseBool)
+}
+
+// DurationVarN behaves like DurationVar, but accepts an ordered list of
+// names. Parse uses the value of the first name present in the environment,
+// logging a deprecation warning if the matched name isn't names[0].
+func (s *Set) DurationVarN(addr *time.Duration, names []string, defaultValue time.Duration) {
+	queueSetVarN(s, addr, names, defaultValue, castDuration)
+}
+
+func queueSetVarN[T any](s *Set, addr *T, names []string, defaultValue T, cast caster[T]) {
+	if len(names) == 0 {
+		panic("envy: VarN called with no names")
+	}
+
+	s.vars = append(s.vars, func() error {
+		return setVarN(addr, names, defaultValue, cast)
+	})
+
+	v := newVar(names[0], defaultValue, "")
+	v.Aliases = names[1:]
+	s.list = append(s.list, v)
+}
+
+func setVarN[T any](addr *T, names []string, defaultValue T, cast caster[T]) error {
+	v, matched, ok := lookupN(names)
+	if !ok {
+		*addr = defaultValue
+
+		return nil
+	}
+
+	if len(names) > 0 && matched != names[0] {
+		log.Printf("envy: %s is deprecated, use %s instead", matched, names[0])
+	}
+
+	cv, err := cast(v)
+	if err != nil {
+		var zero T
+
+		return &VarError{Name: matched, Type: reflect.TypeOf(zero).String(), Err: err}
+	}
+
+	*addr = cv
+
+	return nil
+}
+
+// StringVarN behaves like StringVar, but accepts an ordered list of names
+// instead of a single one, e.g.:
+//
+//	envy.StringVarN(&addr, []string{"HTTP_ADDR", "LISTEN_ADDR", "ADDR"}, ":8080")
+//
+// Parse uses the value of the first name present in the environment, which
+// allows a variable to be renamed without a breaking change: old deployments
+// using a deprecated name keep working, and a warning is logged identifying
+// which name actually matched whenever it isn't names[0].
+func StringVarN(addr *string, names []string, defaultValue string) {
+	Default.StringVarN(addr, names, defaultValue)
+}
+
+// IntVarN is the IntVar equivalent of StringVarN.
+func IntVarN(addr *int, names []string, defaultValue int) {
+	Default.IntVarN(addr, names, defaultValue)
+}
+
+// Int64VarN is the Int64Var equivalent of StringVarN.
+func Int64VarN(addr *int64, names []string, defaultValue int64) {
+	Default.Int64VarN(addr, names, defaultValue)
+}
+
+// UintVarN is the UintVar equivalent of StringVarN.
+func UintVarN(addr *uint, names []string, defaultValue uint) {
+	Default.UintVarN(addr, names, defaultValue)
+}
+
+// Uint64VarN is the Uint64Var equivalent of StringVarN.
+func Uint64VarN(addr *uint64, names []string, defaultValue uint64) {
+	Default.Uint64VarN(addr, names, defaultValue)
+}
+
+// Float64VarN is the Float64Var equivalent of StringVarN.
+func Float64VarN(addr *float64, names []string, defaultValue float64) {
+	Default.Float64VarN(addr, names, defaultValue)
+}
+
+// BoolVarN is the BoolVar equivalent of StringVarN.
+func BoolVarN(addr *bool, names []string, defaultValue bool) {
+	Default.BoolVarN(addr, names, defaultValue)
+}
+
+// DurationVarN is the DurationVar equivalent of StringVarN.
+func DurationVarN(addr *time.Duration, names []string, defaultValue time.Duration) {
+	Default.DurationVarN(addr, names, defaultValue)
+}
@@ -0,0 +1,83 @@
+package envy
+
+import "testing"
+
+func TestStringVarNFallbackOrder(t *testing.T) {
+	t.Setenv("ENVY_TEST_N_LISTEN_ADDR", ":9090")
+
+	s := NewSet("test", ContinueOnError)
+
+	var addr string
+	s.StringVarN(&addr, []string{"ENVY_TEST_N_HTTP_ADDR", "ENVY_TEST_N_LISTEN_ADDR", "ENVY_TEST_N_ADDR"}, ":8080")
+
+	if err := s.Parse(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if addr != ":9090" {
+		t.Errorf("addr = %q, want %q", addr, ":9090")
+	}
+}
+
+func TestStringVarNPrefersPrimaryName(t *testing.T) {
+	t.Setenv("ENVY_TEST_N_HTTP_ADDR", ":7070")
+	t.Setenv("ENVY_TEST_N_LISTEN_ADDR2", ":9090")
+
+	s := NewSet("test", ContinueOnError)
+
+	var addr string
+	s.StringVarN(&addr, []string{"ENVY_TEST_N_HTTP_ADDR", "ENVY_TEST_N_LISTEN_ADDR2"}, ":8080")
+
+	if err := s.Parse(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if addr != ":7070" {
+		t.Errorf("addr = %q, want %q (the primary name should win when both are set)", addr, ":7070")
+	}
+}
+
+func TestStringVarNDefaultWhenNoneSet(t *testing.T) {
+	s := NewSet("test", ContinueOnError)
+
+	var addr string
+	s.StringVarN(&addr, []string{"ENVY_TEST_N_UNSET_A", "ENVY_TEST_N_UNSET_B"}, ":8080")
+
+	if err := s.Parse(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if addr != ":8080" {
+		t.Errorf("addr = %q, want default %q", addr, ":8080")
+	}
+}
+
+func TestIntVarNConvertsMatchedValue(t *testing.T) {
+	t.Setenv("ENVY_TEST_N_PORT_ALIAS", "9999")
+
+	s := NewSet("test", ContinueOnError)
+
+	var port int
+	s.IntVarN(&port, []string{"ENVY_TEST_N_PORT", "ENVY_TEST_N_PORT_ALIAS"}, 8080)
+
+	if err := s.Parse(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if port != 9999 {
+		t.Errorf("port = %d, want 9999", port)
+	}
+}
+
+func TestVarNPanicsOnEmptyNames(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected StringVarN to panic when given no names")
+		}
+	}()
+
+	s := NewSet("test", ContinueOnError)
+
+	var addr string
+	s.StringVarN(&addr, nil, ":8080")
+}
@@ -0,0 +1,358 @@
+package envy
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrorHandling tells a Set how to behave when Parse encounters a variable
+// that cannot be converted to its expected type, mirroring the model used by
+// flag.FlagSet.
+type ErrorHandling int
+
+const (
+	// ContinueOnError causes Parse to return an aggregated error describing
+	// every variable that failed to bind, leaving the decision of what to do
+	// next to the caller.
+	ContinueOnError ErrorHandling = iota
+
+	// ExitOnError causes Parse to print the aggregated error to os.Stderr
+	// and call os.Exit(2).
+	ExitOnError
+
+	// PanicOnError causes Parse to panic as soon as the first variable fails
+	// to bind. This is the behavior of the package-level functions
+	// (StringVar, Parse, etc.), preserved via the Default set.
+	PanicOnError
+)
+
+// Set is a named collection of queued environment variable bindings, with
+// its own ErrorHandling policy. It exposes the same XVar methods as the
+// package-level functions (StringVar, IntVar, ...), which the package-level
+// functions delegate to via Default.
+//
+// A Set's methods are not safe for concurrent use.
+type Set struct {
+	name     string
+	handling ErrorHandling
+	vars     []func() error
+	list     []*Var
+}
+
+// Default is the Set that the package-level functions (StringVar, Parse,
+// ...) delegate to. It uses PanicOnError, preserving the panic-on-bad-value
+// behavior the package has always had.
+var Default = NewSet("", PanicOnError)
+
+// NewSet returns a new, empty Set identified by name (used only to label
+// errors produced by the set) with the given ErrorHandling policy.
+func NewSet(name string, handling ErrorHandling) *Set {
+	return &Set{name: name, handling: handling}
+}
+
+// VarError describes a single variable that Parse failed to bind: the env
+// variable name, the Go type it was being converted to, and the underlying
+// conversion error.
+type VarError struct {
+	Name string
+	Type string
+	Err  error
+}
+
+func (e *VarError) Error() string {
+	return fmt.Sprintf("failed to parse %s as %s: %s", e.Name, e.Type, e.Err)
+}
+
+func (e *VarError) Unwrap() error {
+	return e.Err
+}
+
+// ParseErrors is returned by Set.Parse under ContinueOnError (and printed by
+// it before exiting under ExitOnError) when one or more variables failed to
+// bind. It aggregates every VarError encountered rather than stopping at the
+// first one.
+type ParseErrors []error
+
+func (e ParseErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return fmt.Sprintf("envy: %d error(s) parsing vars:\n\t%s", len(e), strings.Join(msgs, "\n\t"))
+}
+
+// StringVar will assign the value of the env variable name to the memory
+// address of addr. If there is no env variable set, then the defaultValue
+// will be assigned instead. See the package-level StringVar for full
+// details.
+func (s *Set) StringVar(addr *string, name, defaultValue string) {
+	queueSetVar(s, addr, name, defaultValue, castString)
+}
+
+// IntVar will attempt to convert the value of the env variable name to an
+// int, and then assign the converted value to the address of addr. See the
+// package-level IntVar for full details.
+func (s *Set) IntVar(addr *int, name string, defaultValue int) {
+	queueSetVar(s, addr, name, defaultValue, strconv.Atoi)
+}
+
+// Int64Var will attempt to convert the value of the env variable name to an
+// int64, and then assign the converted value to the address of addr. See the
+// package-level Int64Var for full details.
+func (s *Set) Int64Var(addr *int64, name string, defaultValue int64) {
+	queueSetVar(s, addr, name, defaultValue, castInt64)
+}
+
+// UintVar will attempt to convert the value of the env variable name to a
+// uint, and then assign the converted value to the address of addr. See the
+// package-level UintVar for full details.
+func (s *Set) UintVar(addr *uint, name string, defaultValue uint) {
+	queueSetVar(s, addr, name, defaultValue, castUint)
+}
+
+// Uint64Var will attempt to convert the value of the env variable name to a
+// uint64, and then assign the converted value to the address of addr. See
+// the package-level Uint64Var for full details.
+func (s *Set) Uint64Var(addr *uint64, name string, defaultValue uint64) {
+	queueSetVar(s, addr, name, defaultValue, castUint64)
+}
+
+// Float64Var will attempt to convert the value of the env variable name to a
+// float64, and then assign the converted value to the address of addr. See
+// the package-level Float64Var for full details.
+func (s *Set) Float64Var(addr *float64, name string, defaultValue float64) {
+	queueSetVar(s, addr, name, defaultValue, castFloat64)
+}
+
+// BoolVar will attempt to convert the value of the env variable name to a
+// bool, and then assign the converted value to the address of addr. See the
+// package-level BoolVar for full details.
+func (s *Set) BoolVar(addr *bool, name string, defaultValue bool) {
+	queueSetVar(s, addr, name, defaultValue, strconv.ParseBool)
+}
+
+// DurationVar will attempt to convert the value of the env variable name to
+// a time.Duration, and then assign the converted value to the address of
+// addr. See the package-level DurationVar for full details.
+func (s *Set) DurationVar(addr *time.Duration, name string, defaultValue time.Duration) {
+	queueSetVar(s, addr, name, defaultValue, castDuration)
+}
+
+// Parse processes every XVar queued against s and populates the provided
+// memory addresses.
+//
+// Its behavior on a conversion failure depends on s's ErrorHandling: under
+// ContinueOnError or ExitOnError, every queued var is processed and any
+// failures are collected into a ParseErrors, which ContinueOnError returns
+// and ExitOnError prints to os.Stderr before calling os.Exit(2); under
+// PanicOnError (the
+// policy used by Default), processing stops and panics at the first
+// failure, matching the package's original behavior.
+func (s *Set) Parse() error {
+	if s.handling == PanicOnError {
+		for _, fn := range s.vars {
+			if err := fn(); err != nil {
+				panic(s.labelErr(err))
+			}
+		}
+
+		return nil
+	}
+
+	var errs ParseErrors
+
+	for _, fn := range s.vars {
+		if err := fn(); err != nil {
+			errs = append(errs, s.labelErr(err))
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	if s.handling == ExitOnError {
+		fmt.Fprintln(os.Stderr, errs)
+		os.Exit(2)
+	}
+
+	return errs
+}
+
+// labelErr prefixes err with s.name, if it has one, so that errors produced
+// by a named Set can be told apart from those of another.
+func (s *Set) labelErr(err error) error {
+	if s.name == "" {
+		return err
+	}
+
+	return fmt.Errorf("%s: %w", s.name, err)
+}
+
+func queueSetVar[T any](s *Set, addr *T, name string, defaultValue T, cast caster[T]) {
+	queueSetVarUsage(s, addr, name, defaultValue, "", cast)
+}
+
+func queueSetVarUsage[T any](s *Set, addr *T, name string, defaultValue T, usage string, cast caster[T]) {
+	s.vars = append(s.vars, func() error {
+		return setVar(addr, name, defaultValue, cast)
+	})
+	s.list = append(s.list, newVar(name, defaultValue, usage))
+}
+
+func setVar[T any](addr *T, name string, defaultValue T, cast caster[T]) error {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		*addr = defaultValue
+
+		return nil
+	}
+
+	cv, err := cast(v)
+	if err != nil {
+		var zero T
+
+		return &VarError{Name: name, Type: reflect.TypeOf(zero).String(), Err: err}
+	}
+
+	*addr = cv
+
+	return nil
+}
+
+// StringVar will assign the value of the env variable name to the memory
+// address of addr. If there is no env variable set, then the defaultValue will
+// be assigned instead.
+//
+// The configured StringVar will be set when Parse() is called, and is offset
+// to allow env configuration to occur during package inits without values
+// being unpredictably set. See Parse() for more info.
+//
+// If there is an env variable matching name, but it is not set (i.e. it has an
+// empty string value) then the empty value will be used instead of the
+// defaultValue.
+func StringVar(addr *string, name, defaultValue string) {
+	Default.StringVar(addr, name, defaultValue)
+}
+
+// IntVar will attempt to convert the value of the env variable name to an int,
+// and then assign the converted value to the address of addr. If there is no
+// env variable set, then the defaultValue will be assigned instead.
+//
+// The configured IntVar will be set when Parse() is called, and is offset to
+// allow env configuration to occur during package inits without values being
+// unpredictably set. See Parse() for more info.
+//
+// If the value of the env variable name cannot be converted to an int, then
+// this function will panic. This includes if the env variable is set as empty
+// ("").
+func IntVar(addr *int, name string, defaultValue int) {
+	Default.IntVar(addr, name, defaultValue)
+}
+
+// Int64Var will attempt to convert the value of the env variable name to an
+// int64, and then assign the converted value to the address of addr. If there
+// is no env variable set, then the defaultValue will be assigned instead.
+//
+// The configured Int64Var will be set when Parse() is called, and is offset to
+// allow env configuration to occur during package inits without values being
+// unpredictably set. See Parse() for more info.
+//
+// If the value of the env variable name cannot be converted to an int64, then
+// this function will panic. This includes if the env variable is set as empty
+// ("").
+func Int64Var(addr *int64, name string, defaultValue int64) {
+	Default.Int64Var(addr, name, defaultValue)
+}
+
+// UintVar will attempt to convert the value of the env variable name to a uint,
+// and then assign the converted value to the address of addr. If there is no
+// env variable set, then the defaultValue will be assigned instead.
+//
+// The configured UintVar will be set when Parse() is called, and is offset to
+// allow env configuration to occur during package inits without values being
+// unpredictably set. See Parse() for more info.
+//
+// If the value of the env variable name cannot be converted to an uint, then
+// this function will panic. This includes if the env variable is set as empty
+// ("").
+func UintVar(addr *uint, name string, defaultValue uint) {
+	Default.UintVar(addr, name, defaultValue)
+}
+
+// Uint64Var will attempt to convert the value of the env variable name to a
+// uint64, and then assign the converted value to the address of addr. If there
+// is no env variable set, then the defaultValue will be assigned instead.
+//
+// The configured Uint64Var will be set when Parse() is called, and is offset
+// to allow env configuration to occur during package inits without values
+// being unpredictably set. See Parse() for more info.
+//
+// If the value of the env variable name cannot be converted to an uint64, then
+// this function will panic. This includes if the env variable is set as empty
+// ("").
+func Uint64Var(addr *uint64, name string, defaultValue uint64) {
+	Default.Uint64Var(addr, name, defaultValue)
+}
+
+// Float64Var will attempt to convert the value of the env variable name to a
+// float64, and then assign the converted value to the address of addr. If the
+// is no env variable set, then the defaultValue will be assigned instead.
+//
+// The configured Float64Var will be set when Parse() is called, and is offset
+// to allow env configuration to occur during package inits without values
+// being unpredictably set. See Parse() for more info.
+//
+// If the value of the env variable name cannot be converted to a float64, then
+// this function will panic. This includes if the env variable is set as empty
+// ("").
+func Float64Var(addr *float64, name string, defaultValue float64) {
+	Default.Float64Var(addr, name, defaultValue)
+}
+
+// BoolVar will attempt to convert the value of the env variable name to a bool
+// and then assign the converted value to the address of addr. If there is no
+// env variable set, then the defaultValue will be assigned instead.
+//
+// The configured BoolVar will be set when Parse() is called, and is offset to
+// allow env configuration to occur during package inits without values being
+// unpredictably set. See Parse() for more info.
+//
+// If the value of the env variable name cannot be converted to a bool, then
+// this function will panic. This includes if the env variable is set as empty
+// ("").
+func BoolVar(addr *bool, name string, defaultValue bool) {
+	Default.BoolVar(addr, name, defaultValue)
+}
+
+// DurationVar will attempt to convert the value of the env variable name to a
+// time.Duration and then assign the converted value to the address of addr. If
+// there is no env variable set, then the defaultValue will be assigned instead.
+//
+// The configured DurationVar will be set when Parse() is called, and is offset
+// to allow env configuration to occur during package inits without values
+// being unpredictably set. See Parse() for more info.
+//
+// If the value of the env variable name cannot be converted to a time.Duration,
+// then this function will panic. This includes if the env variable is set as
+// empty ("").
+func DurationVar(addr *time.Duration, name string, defaultValue time.Duration) {
+	Default.DurationVar(addr, name, defaultValue)
+}
+
+// Parse will process all configured XVars that have been set (e.g. StringVar)
+// and populate the provided memory addresses (or panic on error).
+//
+// Parse should not be called from within the init function of any package, and
+// should instead be called from main (or as early on in the program's
+// operation as possible). This is to allow any additional env configuration,
+// i.e. via .env files, that would normally occur within a package init function
+// to all conclude safely (and predictably) before any env vars are fetched.
+func Parse() {
+	_ = Default.Parse()
+}
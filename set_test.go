@@ -0,0 +1,131 @@
+package envy
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestSetContinueOnErrorAggregates(t *testing.T) {
+	t.Setenv("ENVY_TEST_SET_BAD_A", "not-an-int")
+	t.Setenv("ENVY_TEST_SET_BAD_B", "also-not-an-int")
+
+	s := NewSet("test", ContinueOnError)
+
+	var a, b int
+	s.IntVar(&a, "ENVY_TEST_SET_BAD_A", 0)
+	s.IntVar(&b, "ENVY_TEST_SET_BAD_B", 0)
+
+	err := s.Parse()
+	if err == nil {
+		t.Fatal("expected an aggregated error")
+	}
+
+	var errs ParseErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected ParseErrors, got %T: %v", err, err)
+	}
+
+	if len(errs) != 2 {
+		t.Fatalf("expected both bad vars reported, got %d: %v", len(errs), errs)
+	}
+}
+
+func TestSetContinueOnErrorStillSetsGoodVars(t *testing.T) {
+	t.Setenv("ENVY_TEST_SET_GOOD", "42")
+	t.Setenv("ENVY_TEST_SET_BAD", "nope")
+
+	s := NewSet("test", ContinueOnError)
+
+	var good, bad int
+	s.IntVar(&good, "ENVY_TEST_SET_GOOD", 0)
+	s.IntVar(&bad, "ENVY_TEST_SET_BAD", 0)
+
+	if err := s.Parse(); err == nil {
+		t.Fatal("expected an error from the bad var")
+	}
+
+	if good != 42 {
+		t.Errorf("good = %d, want 42", good)
+	}
+}
+
+func TestSetParseReturnsNilOnSuccess(t *testing.T) {
+	s := NewSet("test", ContinueOnError)
+
+	var name string
+	s.StringVar(&name, "ENVY_TEST_SET_UNSET_OK", "default")
+
+	if err := s.Parse(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if name != "default" {
+		t.Errorf("name = %q, want %q", name, "default")
+	}
+}
+
+func TestSetPanicOnErrorPanics(t *testing.T) {
+	t.Setenv("ENVY_TEST_SET_PANIC", "nope")
+
+	s := NewSet("test", PanicOnError)
+
+	var a int
+	s.IntVar(&a, "ENVY_TEST_SET_PANIC", 0)
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected Parse to panic")
+		}
+	}()
+
+	s.Parse()
+}
+
+func TestSetLabelsErrorsWithName(t *testing.T) {
+	t.Setenv("ENVY_TEST_SET_LABEL", "nope")
+
+	s := NewSet("mysvc", ContinueOnError)
+
+	var a int
+	s.IntVar(&a, "ENVY_TEST_SET_LABEL", 0)
+
+	err := s.Parse()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if got := err.Error(); !strings.Contains(got, "mysvc") {
+		t.Errorf("expected error to mention the set name %q, got: %s", "mysvc", got)
+	}
+}
+
+// TestSetExitOnErrorExits drives a subprocess, since ExitOnError calls
+// os.Exit and would otherwise kill the test binary itself.
+func TestSetExitOnErrorExits(t *testing.T) {
+	if os.Getenv("ENVY_TEST_EXIT_ON_ERROR_HELPER") == "1" {
+		s := NewSet("test", ExitOnError)
+
+		var a int
+		s.IntVar(&a, "ENVY_TEST_EXIT_BAD", 0)
+		s.Parse()
+
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=TestSetExitOnErrorExits")
+	cmd.Env = append(os.Environ(), "ENVY_TEST_EXIT_ON_ERROR_HELPER=1", "ENVY_TEST_EXIT_BAD=nope")
+
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("expected the subprocess to exit with an error, got: %v", err)
+	}
+
+	if exitErr.ExitCode() != 2 {
+		t.Errorf("exit code = %d, want 2", exitErr.ExitCode())
+	}
+}
@@ -0,0 +1,208 @@
+package envy
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Number is the set of types the Min, Max and Range constraint helpers can
+// be used with, matching the numeric XVarFunc variants. OneOf accepts any
+// comparable type instead.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Min returns a validate function, for use with an XVarFunc variant, that
+// rejects any value lower than min.
+func Min[T Number](min T) func(T) error {
+	return func(v T) error {
+		if v < min {
+			return fmt.Errorf("must be >= %v, got %v", min, v)
+		}
+
+		return nil
+	}
+}
+
+// Max returns a validate function, for use with an XVarFunc variant, that
+// rejects any value higher than max.
+func Max[T Number](max T) func(T) error {
+	return func(v T) error {
+		if v > max {
+			return fmt.Errorf("must be <= %v, got %v", max, v)
+		}
+
+		return nil
+	}
+}
+
+// Range returns a validate function, for use with an XVarFunc variant, that
+// rejects any value outside of [min, max].
+func Range[T Number](min, max T) func(T) error {
+	return func(v T) error {
+		if v < min || v > max {
+			return fmt.Errorf("must be between %v and %v, got %v", min, max, v)
+		}
+
+		return nil
+	}
+}
+
+// OneOf returns a validate function, for use with any XVarFunc variant, that
+// rejects any value not present in allowed.
+func OneOf[T comparable](allowed ...T) func(T) error {
+	return func(v T) error {
+		for _, a := range allowed {
+			if a == v {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("must be one of %v, got %v", allowed, v)
+	}
+}
+
+func queueSetVarFunc[T any](s *Set, addr *T, name string, defaultValue T, cast caster[T], validate func(T) error) {
+	s.vars = append(s.vars, func() error {
+		_, present := os.LookupEnv(name)
+
+		if err := setVar(addr, name, defaultValue, cast); err != nil {
+			return err
+		}
+
+		if validate == nil || !present {
+			return nil
+		}
+
+		if err := validate(*addr); err != nil {
+			var zero T
+
+			return &VarError{Name: name, Type: reflect.TypeOf(zero).String(), Err: err}
+		}
+
+		return nil
+	})
+
+	s.list = append(s.list, newVar(name, defaultValue, ""))
+}
+
+// StringVarFunc behaves like StringVar, but additionally runs validate
+// against the resolved value when Parse is called, failing the same way a
+// type conversion failure would if validate returns an error. validate is
+// only run when name is present in the environment; a defaultValue used in
+// its place is not validated. The same holds for every XVarFunc variant.
+func (s *Set) StringVarFunc(addr *string, name, defaultValue string, validate func(string) error) {
+	queueSetVarFunc(s, addr, name, defaultValue, castString, validate)
+}
+
+// IntVarFunc behaves like IntVar, but additionally runs validate against the
+// resolved value when Parse is called.
+func (s *Set) IntVarFunc(addr *int, name string, defaultValue int, validate func(int) error) {
+	queueSetVarFunc(s, addr, name, defaultValue, strconv.Atoi, validate)
+}
+
+// Int64VarFunc behaves like Int64Var, but additionally runs validate against
+// the resolved value when Parse is called.
+func (s *Set) Int64VarFunc(addr *int64, name string, defaultValue int64, validate func(int64) error) {
+	queueSetVarFunc(s, addr, name, defaultValue, castInt64, validate)
+}
+
+// UintVarFunc behaves like UintVar, but additionally runs validate against
+// the resolved value when Parse is called.
+func (s *Set) UintVarFunc(addr *uint, name string, defaultValue uint, validate func(uint) error) {
+	queueSetVarFunc(s, addr, name, defaultValue, castUint, validate)
+}
+
+// Uint64VarFunc behaves like Uint64Var, but additionally runs validate
+// against the resolved value when Parse is called.
+func (s *Set) Uint64VarFunc(addr *uint64, name string, defaultValue uint64, validate func(uint64) error) {
+	queueSetVarFunc(s, addr, name, defaultValue, castUint64, validate)
+}
+
+// Float64VarFunc behaves like Float64Var, but additionally runs validate
+// against the resolved value when Parse is called.
+func (s *Set) Float64VarFunc(addr *float64, name string, defaultValue float64, validate func(float64) error) {
+	queueSetVarFunc(s, addr, name, defaultValue, castFloat64, validate)
+}
+
+// BoolVarFunc behaves like BoolVar, but additionally runs validate against
+// the resolved value when Parse is called.
+func (s *Set) BoolVarFunc(addr *bool, name string, defaultValue bool, validate func(bool) error) {
+	queueSetVarFunc(s, addr, name, defaultValue, strconv.ParseBool, validate)
+}
+
+// DurationVarFunc behaves like DurationVar, but additionally runs validate
+// against the resolved value when Parse is called.
+func (s *Set) DurationVarFunc(addr *time.Duration, name string, defaultValue time.Duration, validate func(time.Duration) error) {
+	queueSetVarFunc(s, addr, name, defaultValue, castDuration, validate)
+}
+
+// Validate registers fn to run against the raw value of the env variable
+// name when Parse is called, independently of any XVar queued for that
+// name. It is a no-op if name is not present in the environment. Like the
+// XVarFunc family, a failing fn is reported as any other binding failure,
+// following s's ErrorHandling policy.
+func (s *Set) Validate(name string, fn func(string) error) {
+	s.vars = append(s.vars, func() error {
+		raw, ok := os.LookupEnv(name)
+		if !ok {
+			return nil
+		}
+
+		if err := fn(raw); err != nil {
+			return &VarError{Name: name, Type: "validate", Err: err}
+		}
+
+		return nil
+	})
+}
+
+// StringVarFunc queues addr against Default. See Set.StringVarFunc.
+func StringVarFunc(addr *string, name, defaultValue string, validate func(string) error) {
+	Default.StringVarFunc(addr, name, defaultValue, validate)
+}
+
+// IntVarFunc queues addr against Default. See Set.IntVarFunc.
+func IntVarFunc(addr *int, name string, defaultValue int, validate func(int) error) {
+	Default.IntVarFunc(addr, name, defaultValue, validate)
+}
+
+// Int64VarFunc queues addr against Default. See Set.Int64VarFunc.
+func Int64VarFunc(addr *int64, name string, defaultValue int64, validate func(int64) error) {
+	Default.Int64VarFunc(addr, name, defaultValue, validate)
+}
+
+// UintVarFunc queues addr against Default. See Set.UintVarFunc.
+func UintVarFunc(addr *uint, name string, defaultValue uint, validate func(uint) error) {
+	Default.UintVarFunc(addr, name, defaultValue, validate)
+}
+
+// Uint64VarFunc queues addr against Default. See Set.Uint64VarFunc.
+func Uint64VarFunc(addr *uint64, name string, defaultValue uint64, validate func(uint64) error) {
+	Default.Uint64VarFunc(addr, name, defaultValue, validate)
+}
+
+// Float64VarFunc queues addr against Default. See Set.Float64VarFunc.
+func Float64VarFunc(addr *float64, name string, defaultValue float64, validate func(float64) error) {
+	Default.Float64VarFunc(addr, name, defaultValue, validate)
+}
+
+// BoolVarFunc queues addr against Default. See Set.BoolVarFunc.
+func BoolVarFunc(addr *bool, name string, defaultValue bool, validate func(bool) error) {
+	Default.BoolVarFunc(addr, name, defaultValue, validate)
+}
+
+// DurationVarFunc queues addr against Default. See Set.DurationVarFunc.
+func DurationVarFunc(addr *time.Duration, name string, defaultValue time.Duration, validate func(time.Duration) error) {
+	Default.DurationVarFunc(addr, name, defaultValue, validate)
+}
+
+// Validate registers fn against Default. See Set.Validate.
+func Validate(name string, fn func(string) error) {
+	Default.Validate(name, fn)
+}
@@ -0,0 +1,75 @@
+package envy
+
+import "testing"
+
+func TestMinMaxRangeOneOf(t *testing.T) {
+	if err := Min(10)(9); err == nil {
+		t.Error("Min(10)(9) should fail")
+	}
+
+	if err := Min(10)(10); err != nil {
+		t.Errorf("Min(10)(10) should pass, got %v", err)
+	}
+
+	if err := Max(10)(11); err == nil {
+		t.Error("Max(10)(11) should fail")
+	}
+
+	if err := Range(1, 10)(0); err == nil {
+		t.Error("Range(1, 10)(0) should fail")
+	}
+
+	if err := Range(1, 10)(5); err != nil {
+		t.Errorf("Range(1, 10)(5) should pass, got %v", err)
+	}
+
+	if err := OneOf("a", "b")("c"); err == nil {
+		t.Error(`OneOf("a", "b")("c") should fail`)
+	}
+
+	if err := OneOf("a", "b")("b"); err != nil {
+		t.Errorf(`OneOf("a", "b")("b") should pass, got %v`, err)
+	}
+}
+
+func TestIntVarFuncSkipsValidationWhenUnset(t *testing.T) {
+	s := NewSet("test", ContinueOnError)
+
+	var port int
+	s.IntVarFunc(&port, "ENVY_TEST_UNSET_PORT", 0, Range(1024, 65535))
+
+	if err := s.Parse(); err != nil {
+		t.Fatalf("Parse should not validate an out-of-range default for an unset var, got: %v", err)
+	}
+
+	if port != 0 {
+		t.Errorf("port = %d, want 0", port)
+	}
+}
+
+func TestIntVarFuncValidatesWhenSet(t *testing.T) {
+	t.Setenv("ENVY_TEST_SET_PORT", "80")
+
+	s := NewSet("test", ContinueOnError)
+
+	var port int
+	s.IntVarFunc(&port, "ENVY_TEST_SET_PORT", 0, Range(1024, 65535))
+
+	if err := s.Parse(); err == nil {
+		t.Fatal("Parse should reject an out-of-range value that was actually set")
+	}
+}
+
+func TestValidateNoopWhenUnset(t *testing.T) {
+	s := NewSet("test", ContinueOnError)
+
+	s.Validate("ENVY_TEST_UNSET_VALIDATE", func(string) error {
+		t.Fatal("validate fn should not run for an unset var")
+
+		return nil
+	})
+
+	if err := s.Parse(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}